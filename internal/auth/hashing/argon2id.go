@@ -0,0 +1,154 @@
+package hashing
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	DefaultArgon2idTime    = 1
+	DefaultArgon2idMemory  = 64 * 1024
+	DefaultArgon2idThreads = 4
+	argon2idSaltLen        = 16
+	argon2idKeyLen         = 32
+)
+
+// argon2idHashComparer hashes and compares passwords using argon2id,
+// encoding hashes in the standard PHC string format:
+// $argon2id$v=19$m=65536,t=1,p=4$<salt>$<hash>
+type argon2idHashComparer struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	dummy   dummyCache
+}
+
+// NewArgon2idHashComparer returns a HashComparer backed by argon2id with
+// the given parameters. Zero values fall back to the package defaults.
+func NewArgon2idHashComparer(time, memory uint32, threads uint8) HashComparer {
+	if time == 0 {
+		time = DefaultArgon2idTime
+	}
+	if memory == 0 {
+		memory = DefaultArgon2idMemory
+	}
+	if threads == 0 {
+		threads = DefaultArgon2idThreads
+	}
+	return &argon2idHashComparer{time: time, memory: memory, threads: threads}
+}
+
+func (a *argon2idHashComparer) Hash(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("hashing: generate argon2id salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.time, a.memory, a.threads, argon2idKeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, a.memory, a.time, a.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a *argon2idHashComparer) Compare(password, hash string) bool {
+	ok, err := a.compare(password, hash)
+	return err == nil && ok
+}
+
+// NeedsRehash reports whether hash's time, memory, or thread parameters
+// are weaker than a's currently configured parameters.
+func (a *argon2idHashComparer) NeedsRehash(hash string) bool {
+	params, _, _, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return false
+	}
+	return params.time < a.time || params.memory < a.memory || params.threads < a.threads
+}
+
+// VerifyUser is the recommended entry point for login handlers; see the
+// HashComparer.VerifyUser doc comment for its timing-attack rationale.
+func (a *argon2idHashComparer) VerifyUser(lookup func() (string, bool), password string) (bool, error) {
+	return verifyUser(a, &a.dummy, lookup, password)
+}
+
+func (a *argon2idHashComparer) compare(password, hash string) (bool, error) {
+	params, salt, key, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return false, err
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(candidate, key) == 1 {
+		return true, nil
+	}
+	return false, ErrMismatch
+}
+
+// Verify reports a genuine mismatch as (false, nil). A hash that isn't
+// valid argon2id PHC encoding comes back as (false, err) with err
+// wrapping ErrHashMalformed, ErrHashTooShort, or ErrHashVersionTooNew.
+func (a *argon2idHashComparer) Verify(password, hash string) (bool, error) {
+	ok, err := a.compare(password, hash)
+	if err == nil {
+		return ok, nil
+	}
+	if errors.Is(err, ErrMismatch) {
+		return false, nil
+	}
+	reportStructuralFailure(err)
+	return false, err
+}
+
+type argon2idParams struct {
+	version int
+	memory  uint32
+	time    uint32
+	threads uint8
+}
+
+func decodeArgon2idHash(hash string) (argon2idParams, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: not an argon2id hash", ErrHashMalformed)
+	}
+
+	var params argon2idParams
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &params.version); err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: malformed version: %v", ErrHashMalformed, err)
+	}
+	if params.version > argon2.Version {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: argon2id version %d", ErrHashVersionTooNew, params.version)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: malformed parameters: %v", ErrHashMalformed, err)
+	}
+	if params.time < 1 {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: argon2id time must be >= 1, got %d", ErrHashMalformed, params.time)
+	}
+	if params.threads < 1 {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: argon2id parallelism must be >= 1, got %d", ErrHashMalformed, params.threads)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: malformed salt: %v", ErrHashMalformed, err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: malformed key: %v", ErrHashMalformed, err)
+	}
+	if len(key) < argon2idKeyLen {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: argon2id key", ErrHashTooShort)
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,77 @@
+package hashing
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultBcryptCost is used when no cost is configured.
+const DefaultBcryptCost = bcrypt.DefaultCost
+
+// bcryptHashComparer hashes and compares passwords using bcrypt.
+type bcryptHashComparer struct {
+	cost  int
+	dummy dummyCache
+}
+
+// NewBcryptHashComparer returns a HashComparer backed by bcrypt at the
+// given cost. A cost of 0 falls back to DefaultBcryptCost.
+func NewBcryptHashComparer(cost int) HashComparer {
+	if cost <= 0 {
+		cost = DefaultBcryptCost
+	}
+	return &bcryptHashComparer{cost: cost}
+}
+
+func (b *bcryptHashComparer) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (b *bcryptHashComparer) Compare(password, hash string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// Verify reports a genuine mismatch as (false, nil). Any other bcrypt
+// error — a truncated hash or one written by a version of bcrypt this
+// build doesn't understand — is structural and comes back wrapped in
+// ErrHashMalformed, ErrHashTooShort, or ErrHashVersionTooNew.
+func (b *bcryptHashComparer) Verify(password, hash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	var versionErr bcrypt.HashVersionTooNewError
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return false, nil
+	case errors.Is(err, bcrypt.ErrHashTooShort):
+		err = fmt.Errorf("%w: %v", ErrHashTooShort, err)
+	case errors.As(err, &versionErr):
+		err = fmt.Errorf("%w: %v", ErrHashVersionTooNew, err)
+	default:
+		err = fmt.Errorf("%w: %v", ErrHashMalformed, err)
+	}
+	reportStructuralFailure(err)
+	return false, err
+}
+
+// NeedsRehash reports whether hash was generated at a lower cost than
+// b.cost, by inspecting the cost field in its $2a$NN$... prefix.
+func (b *bcryptHashComparer) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return false
+	}
+	return cost < b.cost
+}
+
+// VerifyUser is the recommended entry point for login handlers; see the
+// HashComparer.VerifyUser doc comment for its timing-attack rationale.
+func (b *bcryptHashComparer) VerifyUser(lookup func() (string, bool), password string) (bool, error) {
+	return verifyUser(b, &b.dummy, lookup, password)
+}
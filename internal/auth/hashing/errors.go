@@ -0,0 +1,27 @@
+package hashing
+
+import "errors"
+
+// Sentinel errors returned by HashComparer.Verify. Callers should branch
+// on these with errors.Is rather than inspecting error strings.
+var (
+	// ErrMismatch indicates the password did not match the hash. This is
+	// an expected outcome for a wrong password, not a system failure.
+	// Verify does not return it: a wrong password comes back as
+	// (false, nil).
+	ErrMismatch = errors.New("hashing: password does not match hash")
+
+	// ErrHashMalformed indicates hash is not a well-formed encoding for
+	// its algorithm (wrong number of fields, bad base64, unparsable
+	// parameters). This points at database corruption or a hash written
+	// by a foreign/incompatible implementation, not a wrong password.
+	ErrHashMalformed = errors.New("hashing: malformed hash")
+
+	// ErrHashTooShort indicates the hash or its decoded key is shorter
+	// than the algorithm requires.
+	ErrHashTooShort = errors.New("hashing: hash too short")
+
+	// ErrHashVersionTooNew indicates the hash was produced by a newer
+	// version of the algorithm than this build knows how to verify.
+	ErrHashVersionTooNew = errors.New("hashing: hash version too new")
+)
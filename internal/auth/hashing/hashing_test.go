@@ -0,0 +1,35 @@
+package hashing
+
+import "testing"
+
+func newTestComparers() map[string]HashComparer {
+	return map[string]HashComparer{
+		"bcrypt":   NewBcryptHashComparer(4), // bcrypt.MinCost, fast for tests
+		"argon2id": NewArgon2idHashComparer(1, 8*1024, 1),
+		"scrypt":   NewScryptHashComparer(16, 8, 1),
+	}
+}
+
+func TestHashComparerRoundTrip(t *testing.T) {
+	for name, comparer := range newTestComparers() {
+		t.Run(name, func(t *testing.T) {
+			hash, err := comparer.Hash("correct horse battery staple")
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+
+			if !comparer.Compare("correct horse battery staple", hash) {
+				t.Error("Compare: correct password did not match its own hash")
+			}
+			if comparer.Compare("wrong password", hash) {
+				t.Error("Compare: wrong password matched")
+			}
+		})
+	}
+}
+
+func TestNewUnknownAlgorithm(t *testing.T) {
+	if _, err := New(Config{Algorithm: "rot13"}); err == nil {
+		t.Fatal("New: expected an error for an unknown algorithm, got nil")
+	}
+}
@@ -0,0 +1,18 @@
+package hashing
+
+import "log"
+
+// StructuralFailureHandler is invoked whenever Verify returns a
+// structural error (malformed hash, unsupported version, etc.) rather
+// than a plain mismatch. The default logs the failure; callers can
+// replace it to emit a metric so operators can distinguish database
+// corruption or foreign bcrypt hashes from ordinary failed logins.
+var StructuralFailureHandler = func(err error) {
+	log.Printf("hashing: structural verification failure: %v", err)
+}
+
+func reportStructuralFailure(err error) {
+	if StructuralFailureHandler != nil {
+		StructuralFailureHandler(err)
+	}
+}
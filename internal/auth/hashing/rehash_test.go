@@ -0,0 +1,54 @@
+package hashing
+
+import "testing"
+
+func TestBcryptNeedsRehash(t *testing.T) {
+	low := NewBcryptHashComparer(4)
+	high := NewBcryptHashComparer(5)
+
+	hash, err := low.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+
+	if low.NeedsRehash(hash) {
+		t.Error("NeedsRehash: false positive at the same cost")
+	}
+	if !high.NeedsRehash(hash) {
+		t.Error("NeedsRehash: should report a lower-cost hash as needing a rehash")
+	}
+}
+
+func TestArgon2idNeedsRehash(t *testing.T) {
+	weak := NewArgon2idHashComparer(1, 8*1024, 1)
+	strong := NewArgon2idHashComparer(2, 8*1024, 1)
+
+	hash, err := weak.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+
+	if weak.NeedsRehash(hash) {
+		t.Error("NeedsRehash: false positive at the same parameters")
+	}
+	if !strong.NeedsRehash(hash) {
+		t.Error("NeedsRehash: should report weaker time parameter as needing a rehash")
+	}
+}
+
+func TestScryptNeedsRehash(t *testing.T) {
+	weak := NewScryptHashComparer(16, 8, 1)
+	strong := NewScryptHashComparer(32, 8, 1)
+
+	hash, err := weak.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+
+	if weak.NeedsRehash(hash) {
+		t.Error("NeedsRehash: false positive at the same parameters")
+	}
+	if !strong.NeedsRehash(hash) {
+		t.Error("NeedsRehash: should report a lower N as needing a rehash")
+	}
+}
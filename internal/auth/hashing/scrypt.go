@@ -0,0 +1,137 @@
+package hashing
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	DefaultScryptN = 1 << 15
+	DefaultScryptR = 8
+	DefaultScryptP = 1
+	scryptSaltLen  = 16
+	scryptKeyLen   = 32
+)
+
+// scryptHashComparer hashes and compares passwords using scrypt, encoding
+// hashes as: $scrypt$n=32768,r=8,p=1$<salt>$<hash>
+type scryptHashComparer struct {
+	n, r, p int
+	dummy   dummyCache
+}
+
+// NewScryptHashComparer returns a HashComparer backed by scrypt with the
+// given cost parameters. Zero values fall back to the package defaults.
+func NewScryptHashComparer(n, r, p int) HashComparer {
+	if n <= 0 {
+		n = DefaultScryptN
+	}
+	if r <= 0 {
+		r = DefaultScryptR
+	}
+	if p <= 0 {
+		p = DefaultScryptP
+	}
+	return &scryptHashComparer{n: n, r: r, p: p}
+}
+
+func (s *scryptHashComparer) Hash(password string) (string, error) {
+	salt := make([]byte, scryptSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("hashing: generate scrypt salt: %w", err)
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, s.n, s.r, s.p, scryptKeyLen)
+	if err != nil {
+		return "", fmt.Errorf("hashing: derive scrypt key: %w", err)
+	}
+
+	return fmt.Sprintf("$scrypt$n=%d,r=%d,p=%d$%s$%s",
+		s.n, s.r, s.p,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (s *scryptHashComparer) Compare(password, hash string) bool {
+	ok, err := s.compare(password, hash)
+	return err == nil && ok
+}
+
+// NeedsRehash reports whether hash's N, r, or p parameters are weaker
+// than s's currently configured parameters.
+func (s *scryptHashComparer) NeedsRehash(hash string) bool {
+	n, r, p, _, _, err := decodeScryptHash(hash)
+	if err != nil {
+		return false
+	}
+	return n < s.n || r < s.r || p < s.p
+}
+
+// VerifyUser is the recommended entry point for login handlers; see the
+// HashComparer.VerifyUser doc comment for its timing-attack rationale.
+func (s *scryptHashComparer) VerifyUser(lookup func() (string, bool), password string) (bool, error) {
+	return verifyUser(s, &s.dummy, lookup, password)
+}
+
+func (s *scryptHashComparer) compare(password, hash string) (bool, error) {
+	n, r, p, salt, key, err := decodeScryptHash(hash)
+	if err != nil {
+		return false, err
+	}
+
+	candidate, err := scrypt.Key([]byte(password), salt, n, r, p, len(key))
+	if err != nil {
+		return false, fmt.Errorf("%w: derive scrypt key: %v", ErrHashMalformed, err)
+	}
+	if subtle.ConstantTimeCompare(candidate, key) == 1 {
+		return true, nil
+	}
+	return false, ErrMismatch
+}
+
+// Verify reports a genuine mismatch as (false, nil). A hash that isn't
+// valid scrypt encoding comes back as (false, err) with err wrapping
+// ErrHashMalformed or ErrHashTooShort.
+func (s *scryptHashComparer) Verify(password, hash string) (bool, error) {
+	ok, err := s.compare(password, hash)
+	if err == nil {
+		return ok, nil
+	}
+	if errors.Is(err, ErrMismatch) {
+		return false, nil
+	}
+	reportStructuralFailure(err)
+	return false, err
+}
+
+func decodeScryptHash(hash string) (n, r, p int, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 5 || parts[1] != "scrypt" {
+		return 0, 0, 0, nil, nil, fmt.Errorf("%w: not a scrypt hash", ErrHashMalformed)
+	}
+
+	if _, err := fmt.Sscanf(parts[2], "n=%d,r=%d,p=%d", &n, &r, &p); err != nil {
+		return 0, 0, 0, nil, nil, fmt.Errorf("%w: malformed parameters: %v", ErrHashMalformed, err)
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return 0, 0, 0, nil, nil, fmt.Errorf("%w: malformed salt: %v", ErrHashMalformed, err)
+	}
+	key, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return 0, 0, 0, nil, nil, fmt.Errorf("%w: malformed key: %v", ErrHashMalformed, err)
+	}
+	if len(key) < scryptKeyLen {
+		return 0, 0, 0, nil, nil, fmt.Errorf("%w: scrypt key", ErrHashTooShort)
+	}
+
+	return n, r, p, salt, key, nil
+}
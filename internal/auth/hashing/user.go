@@ -0,0 +1,45 @@
+package hashing
+
+import "sync"
+
+// dummyPassword is hashed once per HashComparer and compared against on
+// every lookup miss, so a login attempt against an unknown account costs
+// the same as one against a known account with the wrong password.
+const dummyPassword = "convergio-dummy-password-for-constant-time-lookup-misses"
+
+// dummyCache lazily computes and caches the dummy hash for one
+// HashComparer instance, so VerifyUser pays the hashing cost only once
+// rather than on every call with an unknown user.
+type dummyCache struct {
+	once sync.Once
+	hash string
+	err  error
+}
+
+func (c *dummyCache) get(self HashComparer) (string, error) {
+	c.once.Do(func() {
+		c.hash, c.err = self.Hash(dummyPassword)
+	})
+	return c.hash, c.err
+}
+
+// verifyUser implements VerifyUser for any HashComparer: on a lookup
+// miss it still runs a full Verify against the cached dummy hash before
+// reporting invalid credentials, so "unknown user" and "known user,
+// wrong password" take the same amount of time.
+func verifyUser(self HashComparer, cache *dummyCache, lookup func() (hash string, found bool), password string) (bool, error) {
+	hash, found := lookup()
+	if !found {
+		dummyHash, err := cache.get(self)
+		if err != nil {
+			return false, err
+		}
+		hash = dummyHash
+	}
+
+	ok, err := self.Verify(password, hash)
+	if !found {
+		return false, err
+	}
+	return ok, err
+}
@@ -0,0 +1,74 @@
+package hashing
+
+import "testing"
+
+func TestVerifyUserFoundNotFoundParity(t *testing.T) {
+	for name, comparer := range newTestComparers() {
+		t.Run(name, func(t *testing.T) {
+			hash, err := comparer.Hash("correct")
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+
+			t.Run("found, correct password", func(t *testing.T) {
+				ok, err := comparer.VerifyUser(func() (string, bool) { return hash, true }, "correct")
+				if err != nil {
+					t.Fatalf("VerifyUser: unexpected error: %v", err)
+				}
+				if !ok {
+					t.Error("VerifyUser: expected success for the correct password")
+				}
+			})
+
+			t.Run("found, wrong password", func(t *testing.T) {
+				ok, err := comparer.VerifyUser(func() (string, bool) { return hash, true }, "wrong")
+				if err != nil {
+					t.Fatalf("VerifyUser: unexpected error: %v", err)
+				}
+				if ok {
+					t.Error("VerifyUser: expected failure for the wrong password")
+				}
+			})
+
+			t.Run("not found", func(t *testing.T) {
+				called := false
+				ok, err := comparer.VerifyUser(func() (string, bool) {
+					called = true
+					return "", false
+				}, "whatever")
+				if !called {
+					t.Fatal("VerifyUser: lookup was never called")
+				}
+				if err != nil {
+					t.Fatalf("VerifyUser: unexpected error: %v", err)
+				}
+				if ok {
+					t.Error("VerifyUser: expected failure for an unknown user")
+				}
+			})
+		})
+	}
+}
+
+func TestVerifyUserDummyHashIsCached(t *testing.T) {
+	comparer := NewBcryptHashComparer(4)
+
+	lookup := func() (string, bool) { return "", false }
+
+	if _, err := comparer.VerifyUser(lookup, "a"); err != nil {
+		t.Fatalf("VerifyUser: unexpected error: %v", err)
+	}
+
+	b := comparer.(*bcryptHashComparer)
+	first := b.dummy.hash
+	if first == "" {
+		t.Fatal("VerifyUser: dummy hash was not cached after a lookup miss")
+	}
+
+	if _, err := comparer.VerifyUser(lookup, "b"); err != nil {
+		t.Fatalf("VerifyUser: unexpected error: %v", err)
+	}
+	if b.dummy.hash != first {
+		t.Error("VerifyUser: dummy hash should be computed once and reused, not recomputed per call")
+	}
+}
@@ -0,0 +1,118 @@
+package hashing
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVerifyMismatch(t *testing.T) {
+	for name, comparer := range newTestComparers() {
+		t.Run(name, func(t *testing.T) {
+			hash, err := comparer.Hash("correct")
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+
+			ok, err := comparer.Verify("wrong", hash)
+			if ok {
+				t.Error("Verify: wrong password reported as a match")
+			}
+			if err != nil {
+				t.Errorf("Verify: wrong password should be (false, nil), got err=%v", err)
+			}
+		})
+	}
+}
+
+func TestBcryptVerifyStructuralFailures(t *testing.T) {
+	comparer := NewBcryptHashComparer(4)
+
+	t.Run("too short", func(t *testing.T) {
+		ok, err := comparer.Verify("anything", "$2a$10$fooo")
+		if ok {
+			t.Fatal("Verify: malformed hash reported as a match")
+		}
+		if !errors.Is(err, ErrHashTooShort) {
+			t.Fatalf("Verify: expected ErrHashTooShort, got %v", err)
+		}
+	})
+
+	t.Run("version too new", func(t *testing.T) {
+		hash, err := comparer.Hash("anything")
+		if err != nil {
+			t.Fatalf("Hash: %v", err)
+		}
+		futureHash := "$3a$" + strings.TrimPrefix(hash, "$2a$")
+
+		ok, err := comparer.Verify("anything", futureHash)
+		if ok {
+			t.Fatal("Verify: future-version hash reported as a match")
+		}
+		if !errors.Is(err, ErrHashVersionTooNew) {
+			t.Fatalf("Verify: expected ErrHashVersionTooNew, got %v", err)
+		}
+	})
+}
+
+func TestArgon2idVerifyStructuralFailures(t *testing.T) {
+	comparer := NewArgon2idHashComparer(1, 8*1024, 1)
+
+	t.Run("malformed encoding", func(t *testing.T) {
+		ok, err := comparer.Verify("anything", "not-an-argon2id-hash")
+		if ok {
+			t.Fatal("Verify: malformed hash reported as a match")
+		}
+		if !errors.Is(err, ErrHashMalformed) {
+			t.Fatalf("Verify: expected ErrHashMalformed, got %v", err)
+		}
+	})
+
+	// Regression test: a corrupted row with time=0 or parallelism=0 must
+	// not reach argon2.IDKey, which panics on out-of-range parameters.
+	t.Run("zero time and parallelism does not panic", func(t *testing.T) {
+		hash, err := comparer.Hash("anything")
+		if err != nil {
+			t.Fatalf("Hash: %v", err)
+		}
+		corrupted := strings.Replace(hash, "t=1,p=1", "t=0,p=0", 1)
+
+		ok, err := comparer.Verify("anything", corrupted)
+		if ok {
+			t.Fatal("Verify: corrupted hash reported as a match")
+		}
+		if !errors.Is(err, ErrHashMalformed) {
+			t.Fatalf("Verify: expected ErrHashMalformed, got %v", err)
+		}
+	})
+}
+
+func TestScryptVerifyStructuralFailures(t *testing.T) {
+	comparer := NewScryptHashComparer(16, 8, 1)
+
+	t.Run("malformed encoding", func(t *testing.T) {
+		ok, err := comparer.Verify("anything", "not-a-scrypt-hash")
+		if ok {
+			t.Fatal("Verify: malformed hash reported as a match")
+		}
+		if !errors.Is(err, ErrHashMalformed) {
+			t.Fatalf("Verify: expected ErrHashMalformed, got %v", err)
+		}
+	})
+
+	t.Run("non-power-of-two N", func(t *testing.T) {
+		hash, err := comparer.Hash("anything")
+		if err != nil {
+			t.Fatalf("Hash: %v", err)
+		}
+		corrupted := strings.Replace(hash, "n=16,", "n=15,", 1)
+
+		ok, err := comparer.Verify("anything", corrupted)
+		if ok {
+			t.Fatal("Verify: corrupted hash reported as a match")
+		}
+		if !errors.Is(err, ErrHashMalformed) {
+			t.Fatalf("Verify: expected ErrHashMalformed, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+// Package auth wires Convergio's password hashing primitives into the
+// login flow: verifying credentials and transparently upgrading weak
+// hashes once a user proves they know the plaintext.
+package auth
+
+import (
+	"fmt"
+
+	"github.com/vitaled/convergio/internal/auth/hashing"
+)
+
+// CredentialStore persists and retrieves the password hash associated
+// with a user so the login flow can rehash it in place on success.
+type CredentialStore interface {
+	// PasswordHash returns the stored hash for userID. found is false
+	// when no account exists for userID; that is not an error, it lets
+	// HashComparer.VerifyUser fall back to its constant-time dummy path.
+	PasswordHash(userID string) (hash string, found bool, err error)
+
+	// SetPasswordHash persists a newly computed hash for userID.
+	SetPasswordHash(userID, hash string) error
+}
+
+// Authenticator verifies login credentials and rehashes them at the
+// current configured cost when they were stored with weaker parameters.
+type Authenticator struct {
+	Hasher hashing.HashComparer
+	Store  CredentialStore
+}
+
+// NewAuthenticator returns an Authenticator that verifies and rehashes
+// credentials using hasher and store.
+func NewAuthenticator(hasher hashing.HashComparer, store CredentialStore) *Authenticator {
+	return &Authenticator{Hasher: hasher, Store: store}
+}
+
+// Login verifies password against the hash stored for userID, using
+// HashComparer.VerifyUser so that an unknown userID takes the same time
+// as a known one with the wrong password. On a successful login it
+// rehashes the plaintext and persists the result if the stored hash no
+// longer meets the configured cost/parameters, so raising the bcrypt
+// cost rolls out without forcing a password reset. Only hashes of the
+// Hasher's own algorithm are verified: a stored hash of any other
+// algorithm fails Verify as malformed, so Login returns an error for it
+// and does not rehash it.
+//
+// A returned error is always structural (a malformed stored hash or a
+// store failure), never a plain wrong password — callers can treat it
+// as a 500 and alert, and treat (false, nil) as an ordinary 401. A
+// failure to persist the rehash does not count: the user already
+// authenticated successfully, so Login still reports (true, nil) and
+// reports the rehash failure via hashing.StructuralFailureHandler
+// instead, so it doesn't get conflated with an authentication failure.
+func (a *Authenticator) Login(userID, password string) (bool, error) {
+	var storedHash string
+	var lookupErr error
+
+	ok, err := a.Hasher.VerifyUser(func() (string, bool) {
+		var found bool
+		storedHash, found, lookupErr = a.Store.PasswordHash(userID)
+		if lookupErr != nil {
+			return "", false
+		}
+		return storedHash, found
+	}, password)
+
+	if lookupErr != nil {
+		return false, lookupErr
+	}
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, nil
+	}
+
+	if a.Hasher.NeedsRehash(storedHash) {
+		newHash, err := a.Hasher.Hash(password)
+		if err != nil {
+			hashing.StructuralFailureHandler(fmt.Errorf("auth: rehash on login for %q: %w", userID, err))
+			return true, nil
+		}
+		if err := a.Store.SetPasswordHash(userID, newHash); err != nil {
+			hashing.StructuralFailureHandler(fmt.Errorf("auth: persist rehash on login for %q: %w", userID, err))
+			return true, nil
+		}
+	}
+
+	return true, nil
+}
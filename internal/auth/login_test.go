@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vitaled/convergio/internal/auth/hashing"
+)
+
+type fakeCredentialStore struct {
+	hashes map[string]string
+}
+
+func newFakeCredentialStore() *fakeCredentialStore {
+	return &fakeCredentialStore{hashes: map[string]string{}}
+}
+
+func (s *fakeCredentialStore) PasswordHash(userID string) (string, bool, error) {
+	hash, found := s.hashes[userID]
+	return hash, found, nil
+}
+
+func (s *fakeCredentialStore) SetPasswordHash(userID, hash string) error {
+	s.hashes[userID] = hash
+	return nil
+}
+
+func TestAuthenticatorLoginRehashesOnWeakCost(t *testing.T) {
+	store := newFakeCredentialStore()
+	weak := hashing.NewBcryptHashComparer(4)
+
+	oldHash, err := weak.Hash("hunter2")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	store.hashes["alice"] = oldHash
+
+	strong := hashing.NewBcryptHashComparer(5)
+	authn := NewAuthenticator(strong, store)
+
+	ok, err := authn.Login("alice", "hunter2")
+	if err != nil {
+		t.Fatalf("Login: unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("Login: expected success with the correct password")
+	}
+
+	newHash := store.hashes["alice"]
+	if newHash == oldHash {
+		t.Fatal("Login: expected the stored hash to be rehashed at the stronger cost")
+	}
+	if !strong.Compare("hunter2", newHash) {
+		t.Fatal("Login: rehashed hash does not verify against the original password")
+	}
+}
+
+func TestAuthenticatorLoginWrongPassword(t *testing.T) {
+	store := newFakeCredentialStore()
+	comparer := hashing.NewBcryptHashComparer(4)
+
+	hash, err := comparer.Hash("hunter2")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	store.hashes["alice"] = hash
+
+	authn := NewAuthenticator(comparer, store)
+
+	ok, err := authn.Login("alice", "wrong")
+	if err != nil {
+		t.Fatalf("Login: unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatal("Login: expected failure with the wrong password")
+	}
+}
+
+func TestAuthenticatorLoginUnknownUser(t *testing.T) {
+	store := newFakeCredentialStore()
+	comparer := hashing.NewBcryptHashComparer(4)
+	authn := NewAuthenticator(comparer, store)
+
+	ok, err := authn.Login("ghost", "anything")
+	if err != nil {
+		t.Fatalf("Login: unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatal("Login: expected failure for an unknown user")
+	}
+}
+
+type erroringCredentialStore struct {
+	lookupErr error
+}
+
+func (s *erroringCredentialStore) PasswordHash(userID string) (string, bool, error) {
+	return "", false, s.lookupErr
+}
+
+func (s *erroringCredentialStore) SetPasswordHash(userID, hash string) error {
+	return nil
+}
+
+func TestAuthenticatorLoginStoreError(t *testing.T) {
+	wantErr := errors.New("store unavailable")
+	store := &erroringCredentialStore{lookupErr: wantErr}
+	authn := NewAuthenticator(hashing.NewBcryptHashComparer(4), store)
+
+	ok, err := authn.Login("alice", "hunter2")
+	if ok {
+		t.Fatal("Login: expected failure when the store errors")
+	}
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Login: expected the store error to propagate, got %v", err)
+	}
+}